@@ -0,0 +1,184 @@
+package officehours
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdayAbbreviations maps the standard cron day-of-week names (full or
+// three-letter, case-insensitive) to their cron index, where Sunday is 0.
+var weekdayAbbreviations = map[string]int{
+	"sun": 0, "sunday": 0,
+	"mon": 1, "monday": 1,
+	"tue": 2, "tuesday": 2,
+	"wed": 3, "wednesday": 3,
+	"thu": 4, "thursday": 4,
+	"fri": 5, "friday": 5,
+	"sat": 6, "saturday": 6,
+}
+
+// NewScheduleFromCron builds a Schedule from one or more standard 5-field
+// cron expressions of the form "minute-range hour-range * * dow-range",
+// optionally prefixed with "CRON_TZ=<zone>" (e.g.
+// "CRON_TZ=America/Chicago 0 9-17 * * Mon-Fri"). Unlike a normal cron
+// expression, the minute and hour fields here describe a contiguous range of
+// "open" time rather than firing times: "0 9-17" means open from 9:00 to
+// 17:00. The day-of-month and month fields must be "*", and the
+// day-of-week field accepts comma-separated names or numbers (0 is Sunday)
+// and dashed ranges (e.g. "Mon-Fri", "0,6"). A Schedule has only one
+// location: zoneName is used only when no expression sets CRON_TZ (and is
+// then required); when any expression sets CRON_TZ, that zone applies to
+// every expression, including those without their own CRON_TZ, and
+// zoneName is ignored. Every expression that does set CRON_TZ must agree on
+// the same zone -- mixing zones across exprs is rejected rather than
+// silently applying the last one seen to intervals built from earlier
+// expressions.
+func NewScheduleFromCron(exprs []string, zoneName string) (*Schedule, error) {
+	daily := make(map[string][]Interval)
+
+	var explicitZone string
+	for _, expr := range exprs {
+		tz, fields, err := splitCronExpr(expr)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse cron expression %q: %v", expr, err)
+		}
+		if tz != "" {
+			if explicitZone != "" && explicitZone != tz {
+				return nil, fmt.Errorf("conflicting CRON_TZ values %q and %q across expressions", explicitZone, tz)
+			}
+			explicitZone = tz
+		}
+
+		startMinute, endMinute, err := parseCronRange(fields[0], 0, 59)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse minute field in %q: %v", expr, err)
+		}
+		startHour, endHour, err := parseCronRange(fields[1], 0, 23)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse hour field in %q: %v", expr, err)
+		}
+		if fields[2] != "*" || fields[3] != "*" {
+			return nil, fmt.Errorf("only '*' is supported for the day-of-month and month fields in %q", expr)
+		}
+		weekdays, err := parseCronDayOfWeek(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("can't parse day-of-week field in %q: %v", expr, err)
+		}
+
+		interval := Interval{
+			Start: formatKitchen(startHour, startMinute),
+			End:   formatKitchen(endHour, endMinute),
+		}
+		for _, weekday := range weekdays {
+			daily[days[weekday]] = append(daily[days[weekday]], interval)
+		}
+	}
+
+	if explicitZone != "" {
+		zoneName = explicitZone
+	}
+	if zoneName == "" {
+		return nil, fmt.Errorf("no timezone provided, and no expression set CRON_TZ")
+	}
+
+	return NewSchedule(daily, zoneName)
+}
+
+// splitCronExpr pulls an optional leading "CRON_TZ=<zone>" token off of expr
+// and returns the zone name (empty if not present) along with the remaining
+// 5 cron fields.
+func splitCronExpr(expr string) (string, []string, error) {
+	fields := strings.Fields(expr)
+
+	var zoneName string
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "CRON_TZ=") {
+		zoneName = strings.TrimPrefix(fields[0], "CRON_TZ=")
+		fields = fields[1:]
+	}
+
+	if len(fields) != 5 {
+		return "", nil, fmt.Errorf("expected 5 cron fields, got %d", len(fields))
+	}
+
+	return zoneName, fields, nil
+}
+
+// parseCronRange parses a single cron field that is either a bare number or
+// a dashed range (e.g. "9" or "9-17"), validating it falls within [min, max].
+func parseCronRange(field string, min, max int) (start, end int, err error) {
+	parts := strings.SplitN(field, "-", 2)
+
+	start, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("'%s' is not a number", parts[0])
+	}
+	end = start
+	if len(parts) == 2 {
+		end, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, fmt.Errorf("'%s' is not a number", parts[1])
+		}
+	}
+
+	if start < min || start > max || end < min || end > max {
+		return 0, 0, fmt.Errorf("value out of range %d-%d", min, max)
+	}
+
+	return start, end, nil
+}
+
+// parseCronDayOfWeek expands a cron day-of-week field ("*", a comma list, or
+// dashed ranges of names or numbers, e.g. "Mon-Fri" or "0,6") into the cron
+// weekday indices it covers, where Sunday is 0.
+func parseCronDayOfWeek(field string) ([]int, error) {
+	if field == "*" {
+		return []int{0, 1, 2, 3, 4, 5, 6}, nil
+	}
+
+	var weekdays []int
+	for _, group := range strings.Split(field, ",") {
+		parts := strings.SplitN(group, "-", 2)
+
+		start, err := parseCronWeekday(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if len(parts) == 2 {
+			end, err = parseCronWeekday(parts[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for d := start; ; d = (d + 1) % 7 {
+			weekdays = append(weekdays, d)
+			if d == end {
+				break
+			}
+		}
+	}
+
+	return weekdays, nil
+}
+
+// parseCronWeekday parses a single cron day-of-week token, which may be a
+// name (full or three-letter abbreviation, case-insensitive) or a number
+// from 0 (Sunday) to 6 (Saturday).
+func parseCronWeekday(token string) (int, error) {
+	if weekday, ok := weekdayAbbreviations[strings.ToLower(token)]; ok {
+		return weekday, nil
+	}
+	if weekday, err := strconv.Atoi(token); err == nil && weekday >= 0 && weekday <= 6 {
+		return weekday, nil
+	}
+	return 0, fmt.Errorf("unknown day-of-week: %s", token)
+}
+
+// formatKitchen renders an hour and minute as a time.Kitchen formatted
+// string, e.g. formatKitchen(17, 0) returns "5:00PM".
+func formatKitchen(hour, minute int) string {
+	return time.Date(0, time.January, 1, hour, minute, 0, 0, time.UTC).Format(time.Kitchen)
+}
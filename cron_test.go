@@ -0,0 +1,109 @@
+package officehours
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewScheduleFromCron(t *testing.T) {
+	suite := []struct {
+		Name         string
+		Exprs        []string
+		Zone         string
+		Err          string
+		Expectations map[string]bool
+	}{
+		{
+			Name:  "with a weekday business hours range",
+			Exprs: []string{"CRON_TZ=America/Chicago 0 9-17 * * Mon-Fri"},
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 CDT": true,  // within the range
+				"Mon, 07 Aug 2017 20:00:00 CDT": false, // outside the range
+				"Sat, 12 Aug 2017 10:00:00 CDT": false, // weekend not included
+			},
+		},
+		{
+			Name:  "with a comma separated day list and explicit zone",
+			Exprs: []string{"0 9-17 * * 0,6"},
+			Zone:  "America/Chicago",
+			Expectations: map[string]bool{
+				"Sat, 12 Aug 2017 10:00:00 CDT": true,  // Saturday (6)
+				"Sun, 13 Aug 2017 10:00:00 CDT": true,  // Sunday (0)
+				"Mon, 07 Aug 2017 10:00:00 CDT": false, // weekday not included
+			},
+		},
+		{
+			Name:  "with multiple expressions composing a split shift",
+			Exprs: []string{"0 9-12 * * Mon-Fri", "0 13-17 * * Mon-Fri"},
+			Zone:  "America/Chicago",
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 CDT": true,  // morning interval
+				"Mon, 07 Aug 2017 12:30:00 CDT": false, // lunch gap
+				"Mon, 07 Aug 2017 15:00:00 CDT": true,  // afternoon interval
+			},
+		},
+		{
+			Name:  "without a day-of-month wildcard",
+			Exprs: []string{"0 9-17 1 * Mon-Fri"},
+			Zone:  "America/Chicago",
+			Err:   "only '*' is supported for the day-of-month and month fields",
+		},
+		{
+			Name:  "with an unknown day-of-week",
+			Exprs: []string{"0 9-17 * * Mon-Funday"},
+			Zone:  "America/Chicago",
+			Err:   "unknown day-of-week: Funday",
+		},
+		{
+			Name:  "without enough fields",
+			Exprs: []string{"0 9-17 * *"},
+			Zone:  "America/Chicago",
+			Err:   "expected 5 cron fields, got 4",
+		},
+		{
+			Name:  "without any timezone",
+			Exprs: []string{"0 9-17 * * Mon-Fri"},
+			Err:   "no timezone provided, and no expression set CRON_TZ",
+		},
+		{
+			Name:  "with conflicting CRON_TZ values across expressions",
+			Exprs: []string{"CRON_TZ=America/Chicago 0 9-12 * * Mon-Fri", "CRON_TZ=America/Denver 0 13-17 * * Mon-Fri"},
+			Err:   `conflicting CRON_TZ values "America/Chicago" and "America/Denver"`,
+		},
+	}
+
+	for _, test := range suite {
+		t.Run(test.Name, func(t *testing.T) {
+			schedule, err := NewScheduleFromCron(test.Exprs, test.Zone)
+			if test.Err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatal("error unexpectedly nil")
+				}
+				if !strings.Contains(err.Error(), test.Err) {
+					t.Fatalf("expected error message to contain '%s', got '%s'", test.Err, err.Error())
+				}
+				return
+			}
+
+			chicago, err := time.LoadLocation("America/Chicago")
+			if err != nil {
+				t.Fatalf("expected America/Chicago timezone to load properly: %v", err)
+			}
+
+			for s, expected := range test.Expectations {
+				parsed, err := time.ParseInLocation(time.RFC1123, s, chicago)
+				if err != nil {
+					t.Fatalf("parsing time '%s': %v", s, err)
+				}
+				if actual := schedule.InSchedule(parsed); actual != expected {
+					t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+				}
+			}
+		})
+	}
+}
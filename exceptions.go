@@ -0,0 +1,97 @@
+package officehours
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date identifies a calendar date without reference to a time of day or
+// timezone. It's used to key date-specific schedule exceptions.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// dateOf returns the calendar Date of t.
+func dateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// MarshalText implements encoding.TextMarshaler, rendering d as
+// "YYYY-MM-DD". This lets Date be used directly as a JSON object key (see
+// Schedule's MarshalJSON), since encoding/json marshals map keys through
+// TextMarshaler when present.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing a "YYYY-MM-DD"
+// date.
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := time.Parse("2006-01-02", string(text))
+	if err != nil {
+		return err
+	}
+	*d = dateOf(parsed)
+	return nil
+}
+
+// SetException registers a one-off override for a single calendar date,
+// taking precedence over both the weekly schedule and any recurring
+// exception registered with SetRecurringException. A nil or empty
+// intervals means the schedule is closed all day on date; a non-empty
+// slice replaces whatever intervals would otherwise apply.
+func (s *Schedule) SetException(date Date, intervals []Interval) error {
+	parsed, err := parseOptionalIntervals(intervals)
+	if err != nil {
+		return err
+	}
+
+	if s.exceptions == nil {
+		s.exceptions = make(map[Date][]interval)
+	}
+	s.exceptions[date] = parsed
+
+	return nil
+}
+
+// SetRecurringException registers an override that applies every year on
+// the given month and day (e.g. "12-25" for Christmas), for holidays that
+// don't need a specific year. It's consulted after exact-date exceptions
+// but before the regular weekly schedule. A nil or empty intervals means
+// the schedule is closed all day.
+func (s *Schedule) SetRecurringException(monthDay string, intervals []Interval) error {
+	month, day, err := parseMonthDay(monthDay)
+	if err != nil {
+		return fmt.Errorf("can't parse recurring exception date %q: %v", monthDay, err)
+	}
+
+	parsed, err := parseOptionalIntervals(intervals)
+	if err != nil {
+		return err
+	}
+
+	if s.recurring == nil {
+		s.recurring = make(map[string][]interval)
+	}
+	s.recurring[monthDayKey(Date{Month: month, Day: day})] = parsed
+
+	return nil
+}
+
+// parseMonthDay parses a recurring exception key in "MM-DD" form.
+func parseMonthDay(s string) (time.Month, int, error) {
+	parsed, err := time.Parse("01-02", s)
+	if err != nil {
+		return 0, 0, err
+	}
+	return parsed.Month(), parsed.Day(), nil
+}
+
+// monthDayKey returns date's recurring-exception key, in canonical "MM-DD"
+// form.
+func monthDayKey(date Date) string {
+	return fmt.Sprintf("%02d-%02d", int(date.Month), date.Day)
+}
@@ -0,0 +1,98 @@
+package officehours
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleExceptions(t *testing.T) {
+	schedule, err := NewSchedule(
+		map[string][]Interval{"Monday": {{"9:00AM", "5:00PM"}}},
+		"America/Phoenix",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	// 2017-08-14 is a Monday; close it entirely (company shutdown day).
+	if err := schedule.SetException(Date{2017, time.August, 14}, nil); err != nil {
+		t.Fatalf("expected exception to register: %v", err)
+	}
+
+	// 2017-08-21 is also a Monday, but with shortened hours that day.
+	if err := schedule.SetException(
+		Date{2017, time.August, 21},
+		[]Interval{{"9:00AM", "12:00PM"}},
+	); err != nil {
+		t.Fatalf("expected exception to register: %v", err)
+	}
+
+	suite := map[string]bool{
+		"Mon, 07 Aug 2017 10:00:00 MST": true,  // an ordinary Monday
+		"Mon, 14 Aug 2017 10:00:00 MST": false, // closed for the exception
+		"Mon, 21 Aug 2017 10:00:00 MST": true,  // shortened hours, still open
+		"Mon, 21 Aug 2017 14:00:00 MST": false, // shortened hours, now closed
+	}
+	for s, expected := range suite {
+		parsed, err := time.ParseInLocation(time.RFC1123, s, arizona)
+		if err != nil {
+			t.Fatalf("parsing time '%s': %v", s, err)
+		}
+		if actual := schedule.InSchedule(parsed); actual != expected {
+			t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+		}
+	}
+}
+
+func TestScheduleRecurringExceptions(t *testing.T) {
+	schedule, err := NewSchedule(
+		map[string][]Interval{
+			"Monday": {{"9:00AM", "5:00PM"}},
+			"Friday": {{"9:00AM", "5:00PM"}},
+		},
+		"America/Phoenix",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	if err := schedule.SetRecurringException("12-25", nil); err != nil {
+		t.Fatalf("expected recurring exception to register: %v", err)
+	}
+
+	suite := map[string]bool{
+		"Mon, 25 Dec 2017 10:00:00 MST": false, // Christmas, 2017
+		"Fri, 25 Dec 2020 10:00:00 MST": false, // Christmas, 2020 -- a different year
+		"Fri, 26 Dec 2025 10:00:00 MST": true,  // the day after Christmas is unaffected
+	}
+	for s, expected := range suite {
+		parsed, err := time.ParseInLocation(time.RFC1123, s, arizona)
+		if err != nil {
+			t.Fatalf("parsing time '%s': %v", s, err)
+		}
+		if actual := schedule.InSchedule(parsed); actual != expected {
+			t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+		}
+	}
+}
+
+func TestScheduleExceptionErrors(t *testing.T) {
+	schedule, err := NewSchedule(
+		map[string][]Interval{"Monday": {{"9:00AM", "5:00PM"}}},
+		"America/Phoenix",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	err = schedule.SetException(Date{2017, time.August, 14}, []Interval{{"NINE AM", "5:00PM"}})
+	if err == nil || !strings.Contains(err.Error(), "can't parse schedule") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+
+	err = schedule.SetRecurringException("not-a-date", nil)
+	if err == nil || !strings.Contains(err.Error(), "can't parse recurring exception date") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+}
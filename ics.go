@@ -0,0 +1,418 @@
+package officehours
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// icsWeekdays maps RFC 5545 BYDAY day codes to time.Weekday.
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// icsDayCodes is the inverse of icsWeekdays.
+var icsDayCodes = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// icsLine is a single unfolded, parsed iCalendar content line, e.g.
+// "DTSTART;TZID=America/Chicago:20240101T090000" parses into name
+// "DTSTART", params {"TZID": "America/Chicago"}, value "20240101T090000".
+type icsLine struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ImportICS parses the VEVENT blocks of an iCalendar document into
+// Schedules, one per distinct TZID. Only the subset of RFC 5545 needed for
+// weekly business-hours calendars is understood: weekly-recurring events
+// (FREQ=WEEKLY;BYDAY=MO,TU,...) become weekday intervals, BYHOUR/BYMINUTE
+// override DTSTART's own time-of-day when present, EXDATE entries become
+// closed-day exceptions, and UNTIL is accepted but neither parsed nor
+// applied, since Schedule has no notion of an overall expiration. A VEVENT with no RRULE,
+// or one whose FREQ isn't WEEKLY (e.g. a one-off meeting or a daily
+// recurrence), can't be represented as weekday intervals and is rejected
+// with an error rather than silently imported as a weekly recurrence.
+func ImportICS(r io.Reader) (Schedules, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading ICS: %v", err)
+	}
+
+	daily := make(map[string]map[string][]Interval)
+	closedDates := make(map[string][]Date)
+
+	var inEvent bool
+	var dtStart, dtEnd icsLine
+	var rrule map[string]string
+	var exdates []icsLine
+
+	for _, raw := range lines {
+		if raw == "" {
+			continue
+		}
+		line := parseICSLine(raw)
+
+		switch {
+		case line.name == "BEGIN" && line.value == "VEVENT":
+			inEvent = true
+			dtStart, dtEnd, rrule, exdates = icsLine{}, icsLine{}, nil, nil
+		case line.name == "END" && line.value == "VEVENT":
+			if inEvent {
+				event, err := parseICSEvent(dtStart, dtEnd, rrule, exdates)
+				if err != nil {
+					return nil, err
+				}
+				if daily[event.zone] == nil {
+					daily[event.zone] = make(map[string][]Interval)
+				}
+				for _, weekday := range event.weekdays {
+					day := days[weekday]
+					daily[event.zone][day] = append(daily[event.zone][day], event.interval)
+				}
+				closedDates[event.zone] = append(closedDates[event.zone], event.exdates...)
+			}
+			inEvent = false
+		case !inEvent:
+			// outside of a VEVENT block -- e.g. VCALENDAR header lines --
+			// nothing here is needed to build a Schedule.
+		case line.name == "DTSTART":
+			dtStart = line
+		case line.name == "DTEND":
+			dtEnd = line
+		case line.name == "RRULE":
+			rrule = parseICSParams(line.value)
+		case line.name == "EXDATE":
+			exdates = append(exdates, line)
+		}
+	}
+
+	zones := make([]string, 0, len(daily))
+	for zone := range daily {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	schedules := make(Schedules, 0, len(zones))
+	for _, zone := range zones {
+		schedule, err := NewSchedule(daily[zone], zone)
+		if err != nil {
+			return nil, err
+		}
+		for _, date := range closedDates[zone] {
+			if err := schedule.SetException(date, nil); err != nil {
+				return nil, err
+			}
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// icsEvent is a single VEVENT's contribution to a Schedule.
+type icsEvent struct {
+	zone     string
+	weekdays []time.Weekday
+	interval Interval
+	exdates  []Date
+}
+
+// parseICSEvent converts one VEVENT's DTSTART/DTEND/RRULE/EXDATE lines into
+// an icsEvent.
+func parseICSEvent(dtStart, dtEnd icsLine, rrule map[string]string, exdates []icsLine) (icsEvent, error) {
+	if dtStart.value == "" || dtEnd.value == "" {
+		return icsEvent{}, fmt.Errorf("VEVENT is missing DTSTART or DTEND")
+	}
+
+	zone, err := icsZone(dtStart)
+	if err != nil {
+		return icsEvent{}, err
+	}
+
+	start, err := parseICSDateTime(dtStart.value, zone)
+	if err != nil {
+		return icsEvent{}, fmt.Errorf("can't parse DTSTART %q: %v", dtStart.value, err)
+	}
+	end, err := parseICSDateTime(dtEnd.value, zone)
+	if err != nil {
+		return icsEvent{}, fmt.Errorf("can't parse DTEND %q: %v", dtEnd.value, err)
+	}
+
+	if rrule == nil {
+		return icsEvent{}, fmt.Errorf("VEVENT has no RRULE: only recurring FREQ=WEEKLY events are understood")
+	}
+	if freq := rrule["FREQ"]; freq != "WEEKLY" {
+		return icsEvent{}, fmt.Errorf("unsupported RRULE FREQ %q: only FREQ=WEEKLY is understood", freq)
+	}
+
+	startHour, startMinute := start.Hour(), start.Minute()
+	if hour, ok := rrule["BYHOUR"]; ok {
+		if startHour, err = strconv.Atoi(hour); err != nil {
+			return icsEvent{}, fmt.Errorf("can't parse BYHOUR %q: %v", hour, err)
+		}
+	}
+	if minute, ok := rrule["BYMINUTE"]; ok {
+		if startMinute, err = strconv.Atoi(minute); err != nil {
+			return icsEvent{}, fmt.Errorf("can't parse BYMINUTE %q: %v", minute, err)
+		}
+	}
+
+	weekdays := []time.Weekday{start.Weekday()}
+	if byDay, ok := rrule["BYDAY"]; ok {
+		weekdays = weekdays[:0]
+		for _, code := range strings.Split(byDay, ",") {
+			weekday, ok := icsWeekdays[strings.ToUpper(code)]
+			if !ok {
+				return icsEvent{}, fmt.Errorf("unknown BYDAY value: %s", code)
+			}
+			weekdays = append(weekdays, weekday)
+		}
+	}
+
+	var dates []Date
+	for _, exdate := range exdates {
+		exZone := zone
+		if tzid, ok := exdate.params["TZID"]; ok {
+			exZone = tzid
+		}
+		for _, value := range strings.Split(exdate.value, ",") {
+			parsed, err := parseICSDateTime(value, exZone)
+			if err != nil {
+				return icsEvent{}, fmt.Errorf("can't parse EXDATE %q: %v", value, err)
+			}
+			dates = append(dates, dateOf(parsed))
+		}
+	}
+
+	return icsEvent{
+		zone:     zone,
+		weekdays: weekdays,
+		interval: Interval{
+			Start: formatKitchen(startHour, startMinute),
+			End:   formatKitchen(end.Hour(), end.Minute()),
+		},
+		exdates: dates,
+	}, nil
+}
+
+// icsZone resolves the timezone a DTSTART/DTEND line is expressed in: its
+// TZID parameter, "UTC" if the value has a trailing "Z", or an error if
+// neither is present (a "floating" local time, which officehours has no
+// way to anchor to a Schedule's location).
+func icsZone(line icsLine) (string, error) {
+	if tzid, ok := line.params["TZID"]; ok {
+		return tzid, nil
+	}
+	if strings.HasSuffix(line.value, "Z") {
+		return "UTC", nil
+	}
+	return "", fmt.Errorf("%s %q has no TZID and isn't UTC", line.name, line.value)
+}
+
+// parseICSDateTime parses an iCalendar DATE-TIME or DATE value (e.g.
+// "20240101T090000", "20240101T090000Z", or "20240101") in the named zone.
+func parseICSDateTime(value, zoneName string) (time.Time, error) {
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+
+	location, err := time.LoadLocation(zoneName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unknown TZID %q: %v", zoneName, err)
+	}
+
+	if !strings.Contains(value, "T") {
+		return time.ParseInLocation("20060102", value, location)
+	}
+	return time.ParseInLocation("20060102T150405", value, location)
+}
+
+// parseICSParams parses a semicolon-separated "KEY=VALUE" list, as found in
+// an RRULE value, into a map keyed by the upper-cased KEY.
+func parseICSParams(value string) map[string]string {
+	params := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = kv[1]
+		}
+	}
+	return params
+}
+
+// parseICSLine parses a single unfolded iCalendar content line into its
+// name, parameters, and value.
+func parseICSLine(line string) icsLine {
+	colon := strings.IndexByte(line, ':')
+	if colon < 0 {
+		return icsLine{name: strings.ToUpper(line)}
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+
+	parsed := icsLine{name: strings.ToUpper(parts[0]), value: value}
+	if len(parts) > 1 {
+		parsed.params = make(map[string]string, len(parts)-1)
+		for _, param := range parts[1:] {
+			kv := strings.SplitN(param, "=", 2)
+			if len(kv) == 2 {
+				parsed.params[strings.ToUpper(kv[0])] = kv[1]
+			}
+		}
+	}
+	return parsed
+}
+
+// unfoldICS reads lines from r, joining RFC 5545 folded continuation lines
+// (ones beginning with a space or tab) onto the line they continue.
+func unfoldICS(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// ExportICS writes s as an iCalendar document, with one weekly-recurring
+// VEVENT per weekday interval (FREQ=WEEKLY;BYDAY=...), anchored to s's
+// location via TZID. Exact-date exceptions registered via SetException
+// that close a day entirely (a nil/empty intervals override) are emitted
+// as EXDATE lines on that weekday's VEVENT(s), so a holiday closure
+// round-trips through ImportICS. Recurring exceptions (SetRecurringException)
+// and exceptions that override a day's hours rather than closing it have
+// no EXDATE/RRULE equivalent in this subset of RFC 5545 and are not
+// exported.
+func (s *Schedule) ExportICS(w io.Writer) error {
+	weekdayNames := make([]string, 0, len(s.daily))
+	for weekday := range s.daily {
+		weekdayNames = append(weekdayNames, weekday)
+	}
+	sort.Strings(weekdayNames)
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	if _, err := fmt.Fprint(w, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//g5search/officehours//EN\r\n"); err != nil {
+		return err
+	}
+
+	for _, name := range weekdayNames {
+		weekday := weekdayFromName(name)
+		closedDates := s.closedExceptionDatesForWeekday(weekday)
+
+		for i, iv := range s.daily[name] {
+			startAnchor := icsAnchor(weekday)
+			endAnchor := startAnchor
+			if iv.wraps() {
+				endAnchor = endAnchor.AddDate(0, 0, 1)
+			}
+
+			var exdateLine string
+			if len(closedDates) > 0 {
+				values := make([]string, len(closedDates))
+				for j, date := range closedDates {
+					values[j] = formatICSDateTime(dateToUTC(date), iv.startMinutes)
+				}
+				exdateLine = fmt.Sprintf("EXDATE;TZID=%s:%s\r\n", s.location.String(), strings.Join(values, ","))
+			}
+
+			_, err := fmt.Fprintf(w,
+				"BEGIN:VEVENT\r\nUID:%s-%d@officehours\r\nDTSTAMP:%s\r\nDTSTART;TZID=%s:%s\r\nDTEND;TZID=%s:%s\r\nRRULE:FREQ=WEEKLY;BYDAY=%s\r\n%sEND:VEVENT\r\n",
+				name, i, stamp,
+				s.location.String(), formatICSDateTime(startAnchor, iv.startMinutes),
+				s.location.String(), formatICSDateTime(endAnchor, iv.endMinutes),
+				icsDayCodes[weekday],
+				exdateLine,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprint(w, "END:VCALENDAR\r\n")
+	return err
+}
+
+// closedExceptionDatesForWeekday returns, sorted, the exact dates
+// registered via SetException that close weekday entirely (a nil/empty
+// intervals override) -- the only exception shape ExportICS can represent,
+// since EXDATE excludes a whole recurrence instance rather than
+// substituting different hours.
+func (s *Schedule) closedExceptionDatesForWeekday(weekday time.Weekday) []Date {
+	var dates []Date
+	for date, intervals := range s.exceptions {
+		if len(intervals) != 0 {
+			continue
+		}
+		if dateToUTC(date).Weekday() != weekday {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool {
+		a, b := dateToUTC(dates[i]), dateToUTC(dates[j])
+		return a.Before(b)
+	})
+	return dates
+}
+
+// dateToUTC returns date as a UTC time.Time at midnight, so it can be
+// formatted or have its Weekday inspected.
+func dateToUTC(date Date) time.Time {
+	return time.Date(date.Year, date.Month, date.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// weekdayFromName returns the time.Weekday for one of the lowercase day
+// names used as keys in Schedule.daily.
+func weekdayFromName(name string) time.Weekday {
+	for weekday, candidate := range days {
+		if candidate == name {
+			return time.Weekday(weekday)
+		}
+	}
+	return time.Sunday
+}
+
+// icsAnchor returns an arbitrary UTC date that falls on weekday, used as
+// the DTSTART/DTEND date for an exported VEVENT (only its time-of-day is
+// meaningful, since FREQ=WEEKLY;BYDAY recurs every week).
+func icsAnchor(weekday time.Weekday) time.Time {
+	// 2024-01-01 was a Monday.
+	monday := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return monday.AddDate(0, 0, int(weekday)-1)
+}
+
+// formatICSDateTime renders date's year/month/day combined with minutes
+// since midnight as an iCalendar local DATE-TIME value.
+func formatICSDateTime(date time.Time, minutes int) string {
+	return time.Date(date.Year(), date.Month(), date.Day(), minutes/60, minutes%60, 0, 0, time.UTC).
+		Format("20060102T150405")
+}
@@ -0,0 +1,197 @@
+package officehours
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleICS = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+BEGIN:VEVENT
+UID:1@test
+DTSTAMP:20240101T000000Z
+DTSTART;TZID=America/Chicago:20240101T090000
+DTEND;TZID=America/Chicago:20240101T170000
+RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR
+EXDATE;TZID=America/Chicago:20251225T090000
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestImportICS(t *testing.T) {
+	schedules, err := ImportICS(strings.NewReader(sampleICS))
+	if err != nil {
+		t.Fatalf("expected ICS to import, got: %v", err)
+	}
+	if len(schedules) != 1 {
+		t.Fatalf("expected 1 schedule, got %d", len(schedules))
+	}
+	schedule := schedules[0]
+
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatalf("expected America/Chicago timezone to load properly: %v", err)
+	}
+
+	suite := map[string]bool{
+		"Mon, 07 Aug 2017 10:00:00 CDT": true,  // a weekday within hours
+		"Mon, 07 Aug 2017 20:00:00 CDT": false, // a weekday outside hours
+		"Sat, 12 Aug 2017 10:00:00 CDT": false, // weekend, not in BYDAY
+		"Thu, 25 Dec 2025 10:00:00 CST": false, // excluded via EXDATE
+		"Fri, 26 Dec 2025 10:00:00 CST": true,  // the day after is unaffected
+	}
+	for s, expected := range suite {
+		parsed, err := time.ParseInLocation(time.RFC1123, s, chicago)
+		if err != nil {
+			t.Fatalf("parsing time '%s': %v", s, err)
+		}
+		if actual := schedule.InSchedule(parsed); actual != expected {
+			t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+		}
+	}
+}
+
+func TestImportICSRequiresTZIDOrUTC(t *testing.T) {
+	const floating = `BEGIN:VEVENT
+DTSTART:20240101T090000
+DTEND:20240101T170000
+RRULE:FREQ=WEEKLY;BYDAY=MO
+END:VEVENT
+`
+	if _, err := ImportICS(strings.NewReader(floating)); err == nil {
+		t.Error("expected an error for a floating (non-UTC, no TZID) DTSTART")
+	}
+}
+
+func TestImportICSRejectsNonWeeklyRecurrence(t *testing.T) {
+	suite := []struct {
+		Name string
+		ICS  string
+	}{
+		{
+			Name: "with no RRULE at all",
+			ICS: `BEGIN:VEVENT
+DTSTART;TZID=America/Chicago:20240101T090000
+DTEND;TZID=America/Chicago:20240101T170000
+END:VEVENT
+`,
+		},
+		{
+			Name: "with FREQ=DAILY",
+			ICS: `BEGIN:VEVENT
+DTSTART;TZID=America/Chicago:20240101T090000
+DTEND;TZID=America/Chicago:20240101T170000
+RRULE:FREQ=DAILY
+END:VEVENT
+`,
+		},
+	}
+
+	for _, test := range suite {
+		t.Run(test.Name, func(t *testing.T) {
+			if _, err := ImportICS(strings.NewReader(test.ICS)); err == nil {
+				t.Error("expected an error for a non-weekly recurrence")
+			}
+		})
+	}
+}
+
+func TestExportICSRoundTrip(t *testing.T) {
+	original, err := NewSchedule(
+		map[string][]Interval{
+			"Monday": {{"9:00AM", "5:00PM"}},
+			"Friday": {{"8:00PM", "2:00AM"}}, // exercises the overnight wrap on export
+		},
+		"America/Chicago",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := original.ExportICS(&buf); err != nil {
+		t.Fatalf("expected export to succeed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "RRULE:FREQ=WEEKLY;BYDAY=MO") {
+		t.Errorf("expected a Monday RRULE in output, got:\n%s", buf.String())
+	}
+
+	schedules, err := ImportICS(&buf)
+	if err != nil {
+		t.Fatalf("expected re-import to succeed: %v", err)
+	}
+	if len(schedules) != 1 {
+		t.Fatalf("expected 1 schedule, got %d", len(schedules))
+	}
+	roundTripped := schedules[0]
+
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatalf("expected America/Chicago timezone to load properly: %v", err)
+	}
+
+	suite := map[string]bool{
+		"Mon, 07 Aug 2017 10:00:00 CDT": true,  // Monday hours preserved
+		"Fri, 11 Aug 2017 21:00:00 CDT": true,  // Friday night, before wrap
+		"Sat, 12 Aug 2017 01:30:00 CDT": true,  // wrapped past midnight into Saturday
+		"Sat, 12 Aug 2017 03:00:00 CDT": false, // past the wrapped interval
+	}
+	for s, expected := range suite {
+		parsed, err := time.ParseInLocation(time.RFC1123, s, chicago)
+		if err != nil {
+			t.Fatalf("parsing time '%s': %v", s, err)
+		}
+		if actual := roundTripped.InSchedule(parsed); actual != expected {
+			t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+		}
+	}
+}
+
+func TestExportICSRoundTripsExceptions(t *testing.T) {
+	original, err := NewSchedule(
+		map[string][]Interval{"Monday": {{"9:00AM", "5:00PM"}}},
+		"America/Chicago",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+	if err := original.SetException(Date{2017, time.August, 14}, nil); err != nil {
+		t.Fatalf("expected exception to register: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := original.ExportICS(&buf); err != nil {
+		t.Fatalf("expected export to succeed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "EXDATE;TZID=America/Chicago:20170814T090000") {
+		t.Errorf("expected an EXDATE for the closed Monday in output, got:\n%s", buf.String())
+	}
+
+	schedules, err := ImportICS(&buf)
+	if err != nil {
+		t.Fatalf("expected re-import to succeed: %v", err)
+	}
+	roundTripped := schedules[0]
+
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatalf("expected America/Chicago timezone to load properly: %v", err)
+	}
+
+	suite := map[string]bool{
+		"Mon, 07 Aug 2017 10:00:00 CDT": true,  // an ordinary Monday
+		"Mon, 14 Aug 2017 10:00:00 CDT": false, // the excepted Monday
+	}
+	for s, expected := range suite {
+		parsed, err := time.ParseInLocation(time.RFC1123, s, chicago)
+		if err != nil {
+			t.Fatalf("parsing time '%s': %v", s, err)
+		}
+		if actual := roundTripped.InSchedule(parsed); actual != expected {
+			t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package officehours
+
+import "encoding/json"
+
+// jsonInterval is the JSON representation of an Interval: {"start":
+// "9:00AM", "end": "5:00PM"}.
+type jsonInterval struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// scheduleJSON is the on-the-wire shape produced by Schedule.MarshalJSON
+// and consumed by Schedule.UnmarshalJSON:
+//
+//	{
+//	  "timezone": "America/Phoenix",
+//	  "week": {"monday": [{"start": "9:00AM", "end": "5:00PM"}]},
+//	  "exceptions": {"2025-12-25": []},
+//	  "recurring_exceptions": {"12-25": []}
+//	}
+type scheduleJSON struct {
+	Timezone            string                    `json:"timezone"`
+	Week                map[string][]jsonInterval `json:"week"`
+	Exceptions          map[Date][]jsonInterval   `json:"exceptions,omitempty"`
+	RecurringExceptions map[string][]jsonInterval `json:"recurring_exceptions,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, rendering s in the scheduleJSON
+// shape that UnmarshalJSON reads back, so a Schedule can be written to and
+// loaded directly from a config file instead of building it via the
+// map[string][]Interval and zone name accepted by NewSchedule. YAML config
+// libraries that convert to JSON under the hood (e.g. sigs.k8s.io/yaml) get
+// YAML support for free through this pair of methods.
+func (s *Schedule) MarshalJSON() ([]byte, error) {
+	aux := scheduleJSON{
+		Timezone: s.location.String(),
+		Week:     make(map[string][]jsonInterval, len(s.daily)),
+	}
+	for day, intervals := range s.daily {
+		aux.Week[day] = toJSONIntervals(intervals)
+	}
+
+	if len(s.exceptions) > 0 {
+		aux.Exceptions = make(map[Date][]jsonInterval, len(s.exceptions))
+		for date, intervals := range s.exceptions {
+			aux.Exceptions[date] = toJSONIntervals(intervals)
+		}
+	}
+
+	if len(s.recurring) > 0 {
+		aux.RecurringExceptions = make(map[string][]jsonInterval, len(s.recurring))
+		for monthDay, intervals := range s.recurring {
+			aux.RecurringExceptions[monthDay] = toJSONIntervals(intervals)
+		}
+	}
+
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It reuses the same validation
+// NewSchedule already does, via NewSchedule, SetException, and
+// SetRecurringException.
+func (s *Schedule) UnmarshalJSON(data []byte) error {
+	var aux scheduleJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	daily := make(map[string][]Interval, len(aux.Week))
+	for day, intervals := range aux.Week {
+		daily[day] = fromJSONIntervals(intervals)
+	}
+
+	built, err := NewSchedule(daily, aux.Timezone)
+	if err != nil {
+		return err
+	}
+
+	for date, intervals := range aux.Exceptions {
+		if err := built.SetException(date, fromJSONIntervals(intervals)); err != nil {
+			return err
+		}
+	}
+	for monthDay, intervals := range aux.RecurringExceptions {
+		if err := built.SetRecurringException(monthDay, fromJSONIntervals(intervals)); err != nil {
+			return err
+		}
+	}
+
+	*s = *built
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler by rendering the same
+// representation as MarshalJSON, so a Schedule can be used directly as a
+// map key or wherever else a text-based encoder expects TextMarshaler
+// rather than json.Marshaler (e.g. YAML/TOML libraries, or as a value in
+// an env var or flag).
+func (s *Schedule) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the same
+// representation produced by MarshalText/MarshalJSON.
+func (s *Schedule) UnmarshalText(text []byte) error {
+	return s.UnmarshalJSON(text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding Schedules from a JSON
+// array of the objects produced by Schedule.MarshalJSON, so a list of
+// schedules can be embedded directly in a service's config.
+func (s *Schedules) UnmarshalJSON(data []byte) error {
+	var parsed []*Schedule
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// toJSONIntervals converts parsed intervals back into their JSON form. It
+// always returns a non-nil (possibly empty) slice, so a closed exception
+// round-trips as "[]" rather than "null".
+func toJSONIntervals(intervals []interval) []jsonInterval {
+	result := make([]jsonInterval, len(intervals))
+	for i, iv := range intervals {
+		result[i] = jsonInterval{
+			Start: formatKitchen(iv.startMinutes/60, iv.startMinutes%60),
+			End:   formatKitchen(iv.endMinutes/60, iv.endMinutes%60),
+		}
+	}
+	return result
+}
+
+// fromJSONIntervals converts a schedule's JSON intervals into the
+// []Interval form accepted by NewSchedule/SetException/
+// SetRecurringException.
+func fromJSONIntervals(intervals []jsonInterval) []Interval {
+	if len(intervals) == 0 {
+		return nil
+	}
+	result := make([]Interval, len(intervals))
+	for i, iv := range intervals {
+		result[i] = Interval{Start: iv.Start, End: iv.End}
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+package officehours
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleUnmarshalJSON(t *testing.T) {
+	const doc = `{
+		"timezone": "America/Phoenix",
+		"week": {"monday": [{"start": "9:00AM", "end": "5:00PM"}]},
+		"exceptions": {"2017-08-14": []},
+		"recurring_exceptions": {"12-25": []}
+	}`
+
+	var schedule Schedule
+	if err := json.Unmarshal([]byte(doc), &schedule); err != nil {
+		t.Fatalf("expected schedule to unmarshal, got: %v", err)
+	}
+
+	suite := map[string]bool{
+		"Mon, 07 Aug 2017 10:00:00 MST": true,  // an ordinary Monday
+		"Mon, 14 Aug 2017 10:00:00 MST": false, // the exact-date exception
+		"Mon, 25 Dec 2017 10:00:00 MST": false, // closed every year via the recurring exception
+	}
+	for s, expected := range suite {
+		parsed, err := time.ParseInLocation(time.RFC1123, s, arizona)
+		if err != nil {
+			t.Fatalf("parsing time '%s': %v", s, err)
+		}
+		if actual := schedule.InSchedule(parsed); actual != expected {
+			t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+		}
+	}
+}
+
+func TestScheduleUnmarshalJSONErrors(t *testing.T) {
+	suite := []struct {
+		Name string
+		Doc  string
+		Err  string
+	}{
+		{
+			Name: "with a bad weekday name",
+			Doc:  `{"timezone": "America/Phoenix", "week": {"Notaday": [{"start": "9:00AM", "end": "5:00PM"}]}}`,
+			Err:  "unknown weekday name",
+		},
+		{
+			Name: "with a bad time format",
+			Doc:  `{"timezone": "America/Phoenix", "week": {"monday": [{"start": "NINE AM", "end": "5:00PM"}]}}`,
+			Err:  "can't parse schedule",
+		},
+		{
+			Name: "with a bad zone name",
+			Doc:  `{"timezone": "Not/AZone", "week": {"monday": [{"start": "9:00AM", "end": "5:00PM"}]}}`,
+			Err:  "problem parsing zone",
+		},
+	}
+
+	for _, test := range suite {
+		t.Run(test.Name, func(t *testing.T) {
+			var schedule Schedule
+			err := json.Unmarshal([]byte(test.Doc), &schedule)
+			if err == nil {
+				t.Fatal("error unexpectedly nil")
+			}
+			if !strings.Contains(err.Error(), test.Err) {
+				t.Errorf("expected error message to contain '%s', got '%s'", test.Err, err.Error())
+			}
+		})
+	}
+}
+
+func TestScheduleMarshalJSONRoundTrip(t *testing.T) {
+	original, err := NewSchedule(
+		map[string][]Interval{"Monday": {{"9:00AM", "5:00PM"}}},
+		"America/Phoenix",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+	if err := original.SetException(Date{2017, time.August, 14}, nil); err != nil {
+		t.Fatalf("expected exception to register: %v", err)
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("expected schedule to marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"2017-08-14":[]`) {
+		t.Errorf("expected closed exception to marshal as an empty array, got: %s", data)
+	}
+
+	var roundTripped Schedule
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatalf("expected schedule to unmarshal: %v", err)
+	}
+
+	suite := map[string]bool{
+		"Mon, 07 Aug 2017 10:00:00 MST": true,
+		"Mon, 14 Aug 2017 10:00:00 MST": false,
+	}
+	for s, expected := range suite {
+		parsed, err := time.ParseInLocation(time.RFC1123, s, arizona)
+		if err != nil {
+			t.Fatalf("parsing time '%s': %v", s, err)
+		}
+		if actual := roundTripped.InSchedule(parsed); actual != expected {
+			t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+		}
+	}
+}
+
+func TestScheduleMarshalTextRoundTrip(t *testing.T) {
+	original, err := NewSchedule(
+		map[string][]Interval{"Monday": {{"9:00AM", "5:00PM"}}},
+		"America/Phoenix",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	text, err := original.MarshalText()
+	if err != nil {
+		t.Fatalf("expected schedule to marshal: %v", err)
+	}
+
+	var roundTripped Schedule
+	if err := roundTripped.UnmarshalText(text); err != nil {
+		t.Fatalf("expected schedule to unmarshal: %v", err)
+	}
+
+	suite := map[string]bool{
+		"Mon, 07 Aug 2017 10:00:00 MST": true,
+		"Mon, 07 Aug 2017 20:00:00 MST": false,
+	}
+	for s, expected := range suite {
+		parsed, err := time.ParseInLocation(time.RFC1123, s, arizona)
+		if err != nil {
+			t.Fatalf("parsing time '%s': %v", s, err)
+		}
+		if actual := roundTripped.InSchedule(parsed); actual != expected {
+			t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+		}
+	}
+}
+
+func TestSchedulesUnmarshalJSON(t *testing.T) {
+	const doc = `[
+		{"timezone": "America/Phoenix", "week": {"monday": [{"start": "9:00AM", "end": "12:00PM"}]}},
+		{"timezone": "America/Phoenix", "week": {"monday": [{"start": "1:00PM", "end": "5:00PM"}]}}
+	]`
+
+	var schedules Schedules
+	if err := json.Unmarshal([]byte(doc), &schedules); err != nil {
+		t.Fatalf("expected schedules to unmarshal, got: %v", err)
+	}
+	if len(schedules) != 2 {
+		t.Fatalf("expected 2 schedules, got %d", len(schedules))
+	}
+
+	morning := mustParseArizona(t, "Mon, 07 Aug 2017 10:00:00 MST")
+	afternoon := mustParseArizona(t, "Mon, 07 Aug 2017 15:00:00 MST")
+
+	if !schedules.InAny(morning) {
+		t.Error("expected morning to be in schedule")
+	}
+	if !schedules.InAny(afternoon) {
+		t.Error("expected afternoon to be in schedule")
+	}
+}
@@ -0,0 +1,124 @@
+package officehours
+
+import (
+	"strings"
+	"time"
+)
+
+// NextOpen returns the next instant, strictly after t, at which the schedule
+// transitions from closed to open. The second return value is false if the
+// schedule has no upcoming open transition (e.g. it has no intervals at
+// all).
+func (s *Schedule) NextOpen(t time.Time) (time.Time, bool) {
+	return s.nextBoundary(t, true)
+}
+
+// NextClose returns the next instant, strictly after t, at which the
+// schedule transitions from open to closed.
+func (s *Schedule) NextClose(t time.Time) (time.Time, bool) {
+	return s.nextBoundary(t, false)
+}
+
+// NextTransition returns the next instant, strictly after t, at which the
+// schedule's status flips -- whichever of NextOpen or NextClose comes
+// first.
+func (s *Schedule) NextTransition(t time.Time) (time.Time, bool) {
+	open, openOK := s.NextOpen(t)
+	close, closeOK := s.NextClose(t)
+
+	switch {
+	case openOK && closeOK:
+		if close.Before(open) {
+			return close, true
+		}
+		return open, true
+	case openOK:
+		return open, true
+	case closeOK:
+		return close, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// nextBoundary localizes t and walks forward over each day's sorted
+// intervals -- starting from the day before t, so a wrapping interval that
+// opened yesterday is still considered, and running a week plus a day past
+// t -- looking for the next start (wantOpen) or end (!wantOpen) instant
+// strictly after t. Intervals are resolved through intervalsFor, so
+// exact-date and recurring exceptions (holidays, closures) are honored
+// rather than falling straight back to the regular weekly schedule.
+func (s *Schedule) nextBoundary(t time.Time, wantOpen bool) (time.Time, bool) {
+	localized := t.In(s.location)
+
+	var best time.Time
+	found := false
+
+	for offset := -1; offset <= 8; offset++ {
+		day := localized.AddDate(0, 0, offset)
+		weekday := strings.ToLower(day.Weekday().String())
+
+		for _, iv := range s.intervalsFor(dateOf(day), weekday) {
+			endDay := day
+			if iv.wraps() {
+				endDay = day.AddDate(0, 0, 1)
+			}
+			end := relativeDayTime(endDay, iv.endMinutes/60, iv.endMinutes%60)
+			if !end.After(localized) {
+				// this occurrence has already closed, so it can't produce
+				// a boundary still ahead of t.
+				continue
+			}
+
+			candidate := end
+			if wantOpen {
+				candidate = relativeDayTime(day, iv.startMinutes/60, iv.startMinutes%60)
+			}
+			if !candidate.After(localized) {
+				continue
+			}
+
+			if !found || candidate.Before(best) {
+				best = candidate
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// nextAcross returns the earliest result of calling fn on every Schedule in
+// the collection.
+func nextAcross(schedules Schedules, t time.Time, fn func(*Schedule, time.Time) (time.Time, bool)) (time.Time, bool) {
+	var best time.Time
+	found := false
+
+	for _, schedule := range schedules {
+		candidate, ok := fn(schedule, t)
+		if !ok {
+			continue
+		}
+		if !found || candidate.Before(best) {
+			best = candidate
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// NextOpen returns the earliest NextOpen across the collection.
+func (s Schedules) NextOpen(t time.Time) (time.Time, bool) {
+	return nextAcross(s, t, (*Schedule).NextOpen)
+}
+
+// NextClose returns the earliest NextClose across the collection.
+func (s Schedules) NextClose(t time.Time) (time.Time, bool) {
+	return nextAcross(s, t, (*Schedule).NextClose)
+}
+
+// NextTransition returns the earliest NextTransition across the collection.
+func (s Schedules) NextTransition(t time.Time) (time.Time, bool) {
+	return nextAcross(s, t, (*Schedule).NextTransition)
+}
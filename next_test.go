@@ -0,0 +1,158 @@
+package officehours
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseArizona(t *testing.T, s string) time.Time {
+	t.Helper()
+	parsed, err := time.ParseInLocation(time.RFC1123, s, arizona)
+	if err != nil {
+		t.Fatalf("parsing time '%s': %v", s, err)
+	}
+	return parsed
+}
+
+func TestScheduleNextTransitions(t *testing.T) {
+	schedule, err := NewSchedule(
+		map[string][]Interval{"Monday": {{"9:00AM", "5:00PM"}}},
+		"America/Phoenix",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	t.Run("before the interval opens", func(t *testing.T) {
+		from := mustParseArizona(t, "Mon, 07 Aug 2017 08:00:00 MST")
+
+		open, ok := schedule.NextOpen(from)
+		if !ok || !open.Equal(mustParseArizona(t, "Mon, 07 Aug 2017 09:00:00 MST")) {
+			t.Errorf("expected next open at 9AM, got %v (ok=%v)", open, ok)
+		}
+
+		close, ok := schedule.NextClose(from)
+		if !ok || !close.Equal(mustParseArizona(t, "Mon, 07 Aug 2017 17:00:00 MST")) {
+			t.Errorf("expected next close at 5PM, got %v (ok=%v)", close, ok)
+		}
+
+		transition, ok := schedule.NextTransition(from)
+		if !ok || !transition.Equal(open) {
+			t.Errorf("expected next transition to be the open at 9AM, got %v (ok=%v)", transition, ok)
+		}
+	})
+
+	t.Run("while the interval is open", func(t *testing.T) {
+		from := mustParseArizona(t, "Mon, 07 Aug 2017 10:00:00 MST")
+
+		open, ok := schedule.NextOpen(from)
+		if !ok || !open.Equal(mustParseArizona(t, "Mon, 14 Aug 2017 09:00:00 MST")) {
+			t.Errorf("expected next open to be the following Monday, got %v (ok=%v)", open, ok)
+		}
+
+		close, ok := schedule.NextClose(from)
+		if !ok || !close.Equal(mustParseArizona(t, "Mon, 07 Aug 2017 17:00:00 MST")) {
+			t.Errorf("expected next close at 5PM, got %v (ok=%v)", close, ok)
+		}
+
+		transition, ok := schedule.NextTransition(from)
+		if !ok || !transition.Equal(close) {
+			t.Errorf("expected next transition to be the close at 5PM, got %v (ok=%v)", transition, ok)
+		}
+	})
+
+	t.Run("after the interval closes for the week", func(t *testing.T) {
+		from := mustParseArizona(t, "Mon, 07 Aug 2017 20:00:00 MST")
+
+		open, ok := schedule.NextOpen(from)
+		if !ok || !open.Equal(mustParseArizona(t, "Mon, 14 Aug 2017 09:00:00 MST")) {
+			t.Errorf("expected next open to be the following Monday, got %v (ok=%v)", open, ok)
+		}
+
+		close, ok := schedule.NextClose(from)
+		if !ok || !close.Equal(mustParseArizona(t, "Mon, 14 Aug 2017 17:00:00 MST")) {
+			t.Errorf("expected next close to be the following Monday, got %v (ok=%v)", close, ok)
+		}
+	})
+}
+
+func TestScheduleNextTransitionsRespectExceptions(t *testing.T) {
+	schedule, err := NewSchedule(
+		map[string][]Interval{
+			"Monday":    {{"9:00AM", "5:00PM"}},
+			"Tuesday":   {{"9:00AM", "5:00PM"}},
+			"Wednesday": {{"9:00AM", "5:00PM"}},
+		},
+		"America/Phoenix",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	// Aug 14 2017 is a Monday; close it entirely via an exception.
+	if err := schedule.SetException(Date{2017, time.August, 14}, nil); err != nil {
+		t.Fatalf("expected exception to register: %v", err)
+	}
+
+	from := mustParseArizona(t, "Fri, 11 Aug 2017 08:00:00 MST")
+
+	open, ok := schedule.NextOpen(from)
+	if !ok || !open.Equal(mustParseArizona(t, "Tue, 15 Aug 2017 09:00:00 MST")) {
+		t.Errorf("expected next open to skip the excepted Monday and land on Tuesday, got %v (ok=%v)", open, ok)
+	}
+
+	transition, ok := schedule.NextTransition(from)
+	if !ok || !transition.Equal(open) {
+		t.Errorf("expected next transition to also skip the excepted Monday, got %v (ok=%v)", transition, ok)
+	}
+}
+
+func TestScheduleNextTransitionsOvernight(t *testing.T) {
+	schedule, err := NewSchedule(
+		map[string][]Interval{"Friday": {{"8:00PM", "2:00AM"}}},
+		"America/Phoenix",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	from := mustParseArizona(t, "Sat, 12 Aug 2017 01:00:00 MST")
+
+	close, ok := schedule.NextClose(from)
+	if !ok || !close.Equal(mustParseArizona(t, "Sat, 12 Aug 2017 02:00:00 MST")) {
+		t.Errorf("expected next close to be 2AM Saturday (wrapped from Friday), got %v (ok=%v)", close, ok)
+	}
+}
+
+func TestScheduleNextTransitionsNoIntervals(t *testing.T) {
+	schedule, err := NewSchedule(map[string][]Interval{}, "America/Phoenix")
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	if _, ok := schedule.NextOpen(time.Now()); ok {
+		t.Error("expected no next open for a schedule with no intervals")
+	}
+	if _, ok := schedule.NextTransition(time.Now()); ok {
+		t.Error("expected no next transition for a schedule with no intervals")
+	}
+}
+
+func TestSchedulesNextTransitions(t *testing.T) {
+	morning, err := NewSchedule(map[string][]Interval{"Monday": {{"9:00AM", "12:00PM"}}}, "America/Phoenix")
+	if err != nil {
+		t.Fatalf("expected morning schedule to create: %v", err)
+	}
+	afternoon, err := NewSchedule(map[string][]Interval{"Monday": {{"1:00PM", "5:00PM"}}}, "America/Phoenix")
+	if err != nil {
+		t.Fatalf("expected afternoon schedule to create: %v", err)
+	}
+
+	schedules := Schedules{morning, afternoon}
+	from := mustParseArizona(t, "Mon, 07 Aug 2017 08:00:00 MST")
+
+	open, ok := schedules.NextOpen(from)
+	if !ok || !open.Equal(mustParseArizona(t, "Mon, 07 Aug 2017 09:00:00 MST")) {
+		t.Errorf("expected earliest next open across schedules to be 9AM, got %v (ok=%v)", open, ok)
+	}
+}
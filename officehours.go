@@ -1,11 +1,16 @@
 // Package officehours allows you to define a weekly schedule which is timezone
 // aware, and determine if times (in any timezone) fall within the schedule.
+//
+// A day may have more than one open interval (e.g. a lunch closure), and an
+// interval whose end time is earlier than its start time is treated as
+// spanning past midnight into the following day (e.g. a bar that's open
+// "8:00PM"-"2:00AM").
 package officehours
 
 import (
 	"errors"
 	"fmt"
-	"math"
+	"sort"
 	"strings"
 	"time"
 )
@@ -20,6 +25,24 @@ var days = []string{
 	"saturday",
 }
 
+// Interval is a single open period within a day. Start and End must be in
+// time.Kitchen format (e.g. "9:00AM"). If End is earlier in the day than
+// Start, the interval is treated as spanning past midnight into the
+// following day.
+type Interval struct {
+	Start, End string
+}
+
+// interval is the parsed, minute-of-day form of an Interval. A wrapping
+// interval (one that spans past midnight) has endMinutes <= startMinutes.
+type interval struct {
+	startMinutes, endMinutes int
+}
+
+func (iv interval) wraps() bool {
+	return iv.endMinutes <= iv.startMinutes
+}
+
 // Schedules is a collection of Schedule objects.
 type Schedules []*Schedule
 
@@ -51,21 +74,26 @@ func (s Schedules) InAnyWithOffsets(t time.Time, before, after time.Duration) bo
 // which can be queried with a time to see if that time falls in or out of the
 // schedule.
 type Schedule struct {
-	daily    map[string][]string
-	location *time.Location
+	daily      map[string][]interval
+	exceptions map[Date][]interval
+	recurring  map[string][]interval
+	location   *time.Location
 }
 
 // NewSchedule instantiates a new schedule. The passed-in map must have valid
 // full day-of-the-week names as keys, though case is ignored (e.g. Monday and
-// monday are valid), and the values must be a slice with a length of exactly
-// two. They correspond to the start and end time for that day, and the format
-// must be time.Kitchen (e.g. 3:00PM). The passed-in zone name is required, and
-// must be known to the operating system (e.g. "America/Los_Angeles", "MST").
-func NewSchedule(daily map[string][]string, zoneName string) (*Schedule, error) {
+// monday are valid), and the values must be a non-empty slice of Intervals
+// for that day. Intervals within a day may overlap the rest of the week
+// arbitrarily (e.g. a morning and an afternoon interval), and an interval
+// that ends earlier than it starts is treated as running past midnight into
+// the next day. The passed-in zone name is required, and must be known to
+// the operating system (e.g. "America/Los_Angeles", "MST").
+func NewSchedule(daily map[string][]Interval, zoneName string) (*Schedule, error) {
 	location, err := time.LoadLocation(zoneName)
 	if err != nil {
 		return nil, fmt.Errorf("problem parsing zone '%s': %v", zoneName, err)
 	}
+
 Days:
 	for provided := range daily {
 		for _, allowed := range days {
@@ -76,26 +104,61 @@ Days:
 		return nil, fmt.Errorf("unknown weekday name: %s", provided)
 	}
 
-	for _, times := range daily {
-		if len(times) != 2 {
-			return nil, errors.New("day schedule must have a start and end time")
+	normalized := make(map[string][]interval, len(daily))
+	for day, intervals := range daily {
+		parsed, err := parseIntervals(intervals)
+		if err != nil {
+			return nil, err
 		}
-		if _, err := time.Parse(time.Kitchen, times[0]); err != nil {
+		normalized[strings.ToLower(day)] = parsed
+	}
+
+	return &Schedule{daily: normalized, location: location}, nil
+}
+
+// parseIntervals validates and converts a day's worth of Intervals into
+// their internal, minute-of-day representation, sorted by start time.
+func parseIntervals(intervals []Interval) ([]interval, error) {
+	if len(intervals) == 0 {
+		return nil, errors.New("day schedule must have at least one interval")
+	}
+
+	return parseOptionalIntervals(intervals)
+}
+
+// parseOptionalIntervals is like parseIntervals, but allows an empty slice
+// (used by exceptions to represent a day with no open intervals at all).
+func parseOptionalIntervals(intervals []Interval) ([]interval, error) {
+	if len(intervals) == 0 {
+		return nil, nil
+	}
+
+	parsed := make([]interval, 0, len(intervals))
+	for _, iv := range intervals {
+		startMinutes, err := parseKitchenMinutes(iv.Start)
+		if err != nil {
 			return nil, fmt.Errorf("can't parse schedule: %v", err)
 		}
-		if _, err := time.Parse(time.Kitchen, times[1]); err != nil {
+		endMinutes, err := parseKitchenMinutes(iv.End)
+		if err != nil {
 			return nil, fmt.Errorf("can't parse schedule: %v", err)
 		}
+		parsed = append(parsed, interval{startMinutes: startMinutes, endMinutes: endMinutes})
 	}
 
-	// we just lowercase all the day names so that it doesn't matter what case
-	// they were provided with.
-	normalizedCase := make(map[string][]string)
-	for day, time := range daily {
-		normalizedCase[strings.ToLower(day)] = time
-	}
+	sort.Slice(parsed, func(i, j int) bool { return parsed[i].startMinutes < parsed[j].startMinutes })
+
+	return parsed, nil
+}
 
-	return &Schedule{daily: normalizedCase, location: location}, nil
+// parseKitchenMinutes parses a time.Kitchen formatted string (e.g. "9:00AM")
+// into minutes since midnight.
+func parseKitchenMinutes(s string) (int, error) {
+	parsed, err := time.Parse(time.Kitchen, s)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
 }
 
 // InSchedule takes a time and determines if it falls under the weekly
@@ -112,41 +175,68 @@ func (s Schedule) InSchedule(t time.Time) bool {
 // some of them always shut down a little earlier and start up a little later.
 func (s Schedule) InScheduleWithOffsets(t time.Time, before time.Duration, after time.Duration) bool {
 	localized := t.In(s.location)
-	times, found := s.daily[strings.ToLower(localized.Weekday().String())]
-	if !found {
-		return false
+
+	weekday := strings.ToLower(localized.Weekday().String())
+	for _, iv := range s.intervalsFor(dateOf(localized), weekday) {
+		if s.matches(localized, localized, iv, before, after) {
+			return true
+		}
+	}
+
+	// An interval on the *previous* day that wraps past midnight can still
+	// be open early this morning, so check yesterday's wrapping intervals
+	// against today's date too.
+	yesterday := localized.AddDate(0, 0, -1)
+	previousWeekday := strings.ToLower(yesterday.Weekday().String())
+	for _, iv := range s.intervalsFor(dateOf(yesterday), previousWeekday) {
+		if !iv.wraps() {
+			continue
+		}
+		if s.matches(yesterday, localized, iv, before, after) {
+			return true
+		}
 	}
 
-	// these were all validated good in the constructor
-	start, _ := time.Parse(time.Kitchen, times[0])
-	start = start.Add(before)
-	end, _ := time.Parse(time.Kitchen, times[1])
-	end = end.Add(after)
-	startOnDay := relativeDayTime(localized, start.Hour(), start.Minute())
-	endOnDay := relativeDayTime(localized, end.Hour(), end.Minute())
+	return false
+}
+
+// intervalsFor returns the intervals that apply on date: an exact-date
+// exception takes precedence, then a recurring (month/day) exception, and
+// only then the regular weekly schedule for weekday.
+func (s *Schedule) intervalsFor(date Date, weekday string) []interval {
+	if ivs, ok := s.exceptions[date]; ok {
+		return ivs
+	}
+	if ivs, ok := s.recurring[monthDayKey(date)]; ok {
+		return ivs
+	}
+	return s.daily[weekday]
+}
+
+// matches reports whether localized falls within iv, whose start is anchored
+// to startDay and whose end is anchored to the following day when iv wraps
+// past midnight.
+func (s Schedule) matches(startDay, localized time.Time, iv interval, before, after time.Duration) bool {
+	start := relativeDayTime(startDay, iv.startMinutes/60, iv.startMinutes%60).Add(before)
 
-	return localized.After(startOnDay) && localized.Before(endOnDay)
+	endDay := startDay
+	if iv.wraps() {
+		endDay = startDay.AddDate(0, 0, 1)
+	}
+	end := relativeDayTime(endDay, iv.endMinutes/60, iv.endMinutes%60).Add(after)
+
+	return localized.After(start) && localized.Before(end)
 }
 
 // Generates a new time for the same day as localized, in the same zone, but
-// using the passed-in hour and minute.
+// using the passed-in hour and minute. Built with time.Date rather than
+// string-formatting an offset, so that DST "spring forward" gaps and "fall
+// back" overlaps resolve to a well-defined real instant instead of a
+// miscomputed one.
 func relativeDayTime(localized time.Time, hour, minute int) time.Time {
-	_, offsetSeconds := localized.Zone()
-	offsetSeconds = int(math.Abs(float64(offsetSeconds)))
-
-	// as far as I know, this can't fail
-	parsed, _ := time.Parse(
-		time.RFC3339,
-		fmt.Sprintf(
-			"%04d-%02d-%02dT%02d:%02d:00-%02d:00",
-			localized.Year(),
-			localized.Month(),
-			localized.Day(),
-			hour,
-			minute,
-			offsetSeconds/60/60,
-		),
+	return time.Date(
+		localized.Year(), localized.Month(), localized.Day(),
+		hour, minute, 0, 0,
+		localized.Location(),
 	)
-
-	return parsed
 }
@@ -21,16 +21,16 @@ func TestSchedule(t *testing.T) {
 	suite := []struct {
 		Name          string
 		Location      string
-		Schedule      map[string][]string
+		Schedule      map[string][]Interval
 		Err           string
 		Expectations  map[string]bool
 		Before, After time.Duration
 	}{
 		{
 			Name: "with a working schedule",
-			Schedule: map[string][]string{
-				"Monday": []string{"9:00AM", "5:00PM"},
-				"Friday": []string{"9:00AM", "1:00PM"},
+			Schedule: map[string][]Interval{
+				"Monday": {{"9:00AM", "5:00PM"}},
+				"Friday": {{"9:00AM", "1:00PM"}},
 			},
 			Expectations: map[string]bool{
 				"Fri, 11 Aug 2017 11:00:00 MST": true,  // in schedule on day
@@ -42,20 +42,44 @@ func TestSchedule(t *testing.T) {
 		},
 		{
 			Name: "with a working schedule using lowercase day names",
-			Schedule: map[string][]string{
-				"monday": []string{"9:00AM", "5:00PM"},
-				"friday": []string{"9:00AM", "1:00PM"},
+			Schedule: map[string][]Interval{
+				"monday": {{"9:00AM", "5:00PM"}},
+				"friday": {{"9:00AM", "1:00PM"}},
 			},
 			Expectations: map[string]bool{
 				"Fri, 11 Aug 2017 11:00:00 MST": true,  // in schedule on day
 				"Fri, 11 Aug 2017 20:00:00 MST": false, // out of schedule on day
 			},
 		},
+		{
+			Name: "with multiple intervals in a day",
+			Schedule: map[string][]Interval{
+				"Monday": {{"9:00AM", "12:00PM"}, {"1:00PM", "5:00PM"}},
+			},
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 MST": true,  // morning interval
+				"Mon, 07 Aug 2017 12:30:00 MST": false, // lunch gap between intervals
+				"Mon, 07 Aug 2017 15:00:00 MST": true,  // afternoon interval
+				"Mon, 07 Aug 2017 20:00:00 MST": false, // after both intervals
+			},
+		},
+		{
+			Name: "with an overnight interval",
+			Schedule: map[string][]Interval{
+				"Friday": {{"8:00PM", "2:00AM"}},
+			},
+			Expectations: map[string]bool{
+				"Fri, 11 Aug 2017 21:00:00 MST": true,  // Friday night, same day
+				"Sat, 12 Aug 2017 01:30:00 MST": true,  // Saturday morning, wrapped from Friday
+				"Sat, 12 Aug 2017 03:00:00 MST": false, // Saturday, past the wrapped interval
+				"Sat, 12 Aug 2017 21:00:00 MST": false, // Saturday has no schedule of its own
+			},
+		},
 		{
 			Name: "with a bad weekday name",
-			Schedule: map[string][]string{
-				"Shmursday": []string{"9:00AM", "5:00PM"},
-				"Friday":    []string{"9:00AM", "1:00PM"},
+			Schedule: map[string][]Interval{
+				"Shmursday": {{"9:00AM", "5:00PM"}},
+				"Friday":    {{"9:00AM", "1:00PM"}},
 			},
 			Err: "unknown weekday name: Shmursday",
 		},
@@ -65,24 +89,24 @@ func TestSchedule(t *testing.T) {
 			Err:      "problem parsing zone 'West Testakota': cannot find",
 		},
 		{
-			Name: "with a bad weekday name",
-			Schedule: map[string][]string{
-				"Monday": []string{"9:00AM"},
+			Name: "with a day that has no intervals",
+			Schedule: map[string][]Interval{
+				"Monday": {},
 			},
-			Err: "day schedule must have a start and end time",
+			Err: "day schedule must have at least one interval",
 		},
 		{
 			Name: "with a bad time format",
-			Schedule: map[string][]string{
-				"Monday": []string{"NINE AM", "TEN AT NIGHT"},
+			Schedule: map[string][]Interval{
+				"Monday": {{"NINE AM", "TEN AT NIGHT"}},
 			},
 			Err: "can't parse schedule: parsing time \"NINE AM\"",
 		},
 		{
 			Name: "with an offset that places the time in schedule",
-			Schedule: map[string][]string{
-				"Monday": []string{"9:00AM", "5:00PM"},
-				"Friday": []string{"9:00AM", "1:00PM"},
+			Schedule: map[string][]Interval{
+				"Monday": {{"9:00AM", "5:00PM"}},
+				"Friday": {{"9:00AM", "1:00PM"}},
 			},
 			Before: -5 * time.Minute,
 			After:  5 * time.Minute,
@@ -102,8 +126,8 @@ func TestSchedule(t *testing.T) {
 			if test.Location != "" {
 				location = test.Location
 			}
-			scheduleMap := map[string][]string{
-				"Friday": []string{"9:00AM", "1:00PM"},
+			scheduleMap := map[string][]Interval{
+				"Friday": {{"9:00AM", "1:00PM"}},
 			}
 			if test.Schedule != nil {
 				scheduleMap = test.Schedule
@@ -150,7 +174,7 @@ func TestSchedule(t *testing.T) {
 
 func TestSchedules(t *testing.T) {
 	arizonaMorning, err := NewSchedule(
-		map[string][]string{"Monday": []string{"9:00AM", "12:00PM"}},
+		map[string][]Interval{"Monday": {{"9:00AM", "12:00PM"}}},
 		"America/Phoenix",
 	)
 	if err != nil {
@@ -158,7 +182,7 @@ func TestSchedules(t *testing.T) {
 	}
 
 	arizonaAfternoon, err := NewSchedule(
-		map[string][]string{"Monday": []string{"12:00PM", "5:00PM"}},
+		map[string][]Interval{"Monday": {{"12:00PM", "5:00PM"}}},
 		"America/Phoenix",
 	)
 	if err != nil {
@@ -189,3 +213,63 @@ func TestSchedules(t *testing.T) {
 		t.Error("expected night to not be in schedule")
 	}
 }
+
+func TestScheduleDSTTransitions(t *testing.T) {
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("expected America/Los_Angeles timezone to load properly: %v", err)
+	}
+
+	// An overnight bar schedule that straddles the "spring forward" and
+	// "fall back" transitions, which in the US happen at 2:00AM local time.
+	schedule, err := NewSchedule(
+		map[string][]Interval{
+			"Saturday": {{"8:00PM", "3:00AM"}},
+			"Sunday":   {{"8:00PM", "3:00AM"}},
+		},
+		"America/Los_Angeles",
+	)
+	if err != nil {
+		t.Fatalf("expected schedule to create: %v", err)
+	}
+
+	suite := []struct {
+		Name     string
+		Time     time.Time
+		Expected bool
+	}{
+		{
+			// 2025-03-09 is when the US springs forward at 2:00AM local
+			// time; 1:30AM still exists and should be in the wrapped
+			// Saturday interval right up to the transition.
+			Name:     "just before spring forward, still in wrapped interval",
+			Time:     time.Date(2025, time.March, 9, 1, 30, 0, 0, losAngeles),
+			Expected: true,
+		},
+		{
+			Name:     "spring forward, after wrapped interval",
+			Time:     time.Date(2025, time.March, 9, 4, 0, 0, 0, losAngeles),
+			Expected: false,
+		},
+		{
+			// 2025-11-02 is when the US falls back; 1:30AM occurs twice.
+			// Either occurrence should fall within the wrapped interval.
+			Name:     "fall back overlap, still in wrapped interval",
+			Time:     time.Date(2025, time.November, 2, 1, 30, 0, 0, losAngeles),
+			Expected: true,
+		},
+		{
+			Name:     "fall back, after wrapped interval",
+			Time:     time.Date(2025, time.November, 2, 4, 0, 0, 0, losAngeles),
+			Expected: false,
+		},
+	}
+
+	for _, test := range suite {
+		t.Run(test.Name, func(t *testing.T) {
+			if actual := schedule.InSchedule(test.Time); actual != test.Expected {
+				t.Errorf("expected %v, got %v", test.Expected, actual)
+			}
+		})
+	}
+}
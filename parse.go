@@ -0,0 +1,137 @@
+package officehours
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSchedule parses a free-form schedule line of the form
+// "<times> <days> [location]", e.g. "9:00AM-5:00PM Mon-Fri
+// America/Los_Angeles" or "09:00-13:00 Sat,Sun" (which defaults to the
+// local timezone, since no location is given). <times> may be a
+// comma-separated list of intervals (e.g. "9:00AM-12:00PM,1:00PM-5:00PM"),
+// and each endpoint may be given in 12-hour (9am, 9:00AM, 9:00 AM) or
+// 24-hour (09:00, 0900) form. <days> follows the same day-of-week syntax as
+// NewScheduleFromCron: comma-separated names and dashed ranges (e.g.
+// "Mon-Fri", "Mon,Wed,Fri").
+func ParseSchedule(s string) (*Schedule, error) {
+	fields := mergeTimeOfDayFields(strings.Fields(s))
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("expected '<times> <days> [location]', got %q", s)
+	}
+
+	timesField, daysField := fields[0], fields[1]
+	zoneName := "Local"
+	if len(fields) == 3 {
+		zoneName = fields[2]
+	}
+
+	weekdays, err := parseCronDayOfWeek(daysField)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse days %q: %v", daysField, err)
+	}
+
+	intervals, err := parseIntervalList(timesField)
+	if err != nil {
+		return nil, err
+	}
+
+	daily := make(map[string][]Interval, len(weekdays))
+	for _, weekday := range weekdays {
+		daily[days[weekday]] = append(daily[days[weekday]], intervals...)
+	}
+
+	return NewSchedule(daily, zoneName)
+}
+
+// mergeTimeOfDayFields re-joins a bare "AM"/"PM" token (or one leading an
+// otherwise-separate token like "AM-5:00PM" or, when another comma-separated
+// interval immediately follows the meridiem, "AM,1:00PM-5:00PM") onto the
+// previous whitespace-split field, so that a time written with a space
+// before its meridiem (e.g. "9:00 AM") survives strings.Fields without being
+// treated as its own field, even when combined with a comma-separated list
+// of intervals.
+func mergeTimeOfDayFields(fields []string) []string {
+	merged := make([]string, 0, len(fields))
+	for _, field := range fields {
+		upper := strings.ToUpper(field)
+		isMeridiemContinuation := upper == "AM" || upper == "PM" ||
+			strings.HasPrefix(upper, "AM-") || strings.HasPrefix(upper, "PM-") ||
+			strings.HasPrefix(upper, "AM,") || strings.HasPrefix(upper, "PM,")
+		if len(merged) > 0 && isMeridiemContinuation {
+			merged[len(merged)-1] += " " + field
+			continue
+		}
+		merged = append(merged, field)
+	}
+	return merged
+}
+
+// parseIntervalList parses a comma-separated list of "<start>-<end>" time
+// ranges into Intervals.
+func parseIntervalList(field string) ([]Interval, error) {
+	var intervals []Interval
+	for _, rangeStr := range strings.Split(field, ",") {
+		parts := strings.SplitN(rangeStr, "-", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("can't parse time range %q: expected '<start>-<end>'", rangeStr)
+		}
+
+		startHour, startMinute, err := parseFlexibleTime(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		endHour, endMinute, err := parseFlexibleTime(parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		intervals = append(intervals, Interval{
+			Start: formatKitchen(startHour, startMinute),
+			End:   formatKitchen(endHour, endMinute),
+		})
+	}
+
+	return intervals, nil
+}
+
+// parseFlexibleTime parses a single time-of-day given in 12-hour form (9am,
+// 9:00AM, 9:00 AM) or 24-hour form (09:00, 0900).
+func parseFlexibleTime(s string) (hour, minute int, err error) {
+	trimmed := strings.TrimSpace(s)
+	upper := strings.ToUpper(trimmed)
+
+	if strings.HasSuffix(upper, "AM") || strings.HasSuffix(upper, "PM") {
+		numeric := strings.TrimSpace(upper[:len(upper)-2])
+		if !strings.Contains(numeric, ":") {
+			numeric += ":00"
+		}
+
+		parsed, err := time.Parse(time.Kitchen, numeric+upper[len(upper)-2:])
+		if err != nil {
+			return 0, 0, fmt.Errorf("can't parse time %q: %v", s, err)
+		}
+		return parsed.Hour(), parsed.Minute(), nil
+	}
+
+	digits := strings.ReplaceAll(trimmed, ":", "")
+	if len(digits) != 4 {
+		return 0, 0, fmt.Errorf("can't parse time %q: expected HH:MM or HHMM", s)
+	}
+
+	hour, err = strconv.Atoi(digits[:2])
+	if err != nil {
+		return 0, 0, fmt.Errorf("can't parse time %q: %v", s, err)
+	}
+	minute, err = strconv.Atoi(digits[2:])
+	if err != nil {
+		return 0, 0, fmt.Errorf("can't parse time %q: %v", s, err)
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, fmt.Errorf("can't parse time %q: out of range", s)
+	}
+
+	return hour, minute, nil
+}
@@ -0,0 +1,131 @@
+package officehours
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	suite := []struct {
+		Name         string
+		Line         string
+		Err          string
+		Expectations map[string]bool
+	}{
+		{
+			Name: "with a 12-hour range and explicit zone",
+			Line: "9:00AM-5:00PM Mon-Fri America/Los_Angeles",
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 PDT": true,  // within the range
+				"Mon, 07 Aug 2017 20:00:00 PDT": false, // outside the range
+				"Sat, 12 Aug 2017 10:00:00 PDT": false, // weekend not included
+			},
+		},
+		{
+			Name: "with a 24-hour range and a comma day list",
+			Line: "09:00-13:00 Sat,Sun America/Los_Angeles",
+			Expectations: map[string]bool{
+				"Sat, 12 Aug 2017 10:00:00 PDT": true,  // within the range
+				"Sun, 13 Aug 2017 10:00:00 PDT": true,  // within the range
+				"Mon, 07 Aug 2017 10:00:00 PDT": false, // weekday not included
+			},
+		},
+		{
+			Name: "with a time given with a space before the meridiem",
+			Line: "9:00 AM-5:00 PM Mon-Fri America/Los_Angeles",
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 PDT": true,
+				"Mon, 07 Aug 2017 20:00:00 PDT": false,
+			},
+		},
+		{
+			Name: "with a compact 12-hour form",
+			Line: "9am-5pm Mon-Fri America/Los_Angeles",
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 PDT": true,
+				"Mon, 07 Aug 2017 20:00:00 PDT": false,
+			},
+		},
+		{
+			Name: "with a compact 24-hour form",
+			Line: "0900-1700 Mon-Fri America/Los_Angeles",
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 PDT": true,
+				"Mon, 07 Aug 2017 20:00:00 PDT": false,
+			},
+		},
+		{
+			Name: "with multiple comma-separated intervals",
+			Line: "9:00AM-12:00PM,1:00PM-5:00PM Mon-Fri America/Los_Angeles",
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 PDT": true,  // morning interval
+				"Mon, 07 Aug 2017 12:30:00 PDT": false, // lunch gap
+				"Mon, 07 Aug 2017 15:00:00 PDT": true,  // afternoon interval
+			},
+		},
+		{
+			Name: "with multiple comma-separated intervals and a space before the meridiem",
+			Line: "9:00 AM-12:00 PM,1:00 PM-5:00 PM Mon-Fri America/Los_Angeles",
+			Expectations: map[string]bool{
+				"Mon, 07 Aug 2017 10:00:00 PDT": true,  // morning interval
+				"Mon, 07 Aug 2017 12:30:00 PDT": false, // lunch gap
+				"Mon, 07 Aug 2017 15:00:00 PDT": true,  // afternoon interval
+			},
+		},
+		{
+			Name: "without enough fields",
+			Line: "9:00AM-5:00PM",
+			Err:  `expected '<times> <days> [location]'`,
+		},
+		{
+			Name: "with a malformed time range",
+			Line: "9:00AM Mon-Fri America/Los_Angeles",
+			Err:  "expected '<start>-<end>'",
+		},
+	}
+
+	for _, test := range suite {
+		t.Run(test.Name, func(t *testing.T) {
+			schedule, err := ParseSchedule(test.Line)
+			if test.Err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatal("error unexpectedly nil")
+				}
+				if !strings.Contains(err.Error(), test.Err) {
+					t.Fatalf("expected error message to contain '%s', got '%s'", test.Err, err.Error())
+				}
+				return
+			}
+
+			losAngeles, err := time.LoadLocation("America/Los_Angeles")
+			if err != nil {
+				t.Fatalf("expected America/Los_Angeles timezone to load properly: %v", err)
+			}
+
+			for s, expected := range test.Expectations {
+				parsed, err := time.ParseInLocation(time.RFC1123, s, losAngeles)
+				if err != nil {
+					t.Fatalf("parsing time '%s': %v", s, err)
+				}
+				if actual := schedule.InSchedule(parsed); actual != expected {
+					t.Errorf("expected time '%s' InSchedule to be %v, was %v", s, expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestParseScheduleDefaultsToLocalZone(t *testing.T) {
+	schedule, err := ParseSchedule("9:00AM-5:00PM Mon-Fri")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if schedule.location != time.Local {
+		t.Errorf("expected schedule to default to time.Local, got %v", schedule.location)
+	}
+}